@@ -0,0 +1,131 @@
+// Package archive resolves a YouTube channel from any of the ways users
+// commonly refer to one, and dumps every one of its videos to disk as
+// JSON.
+package archive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/denisb0/youtube-experiments/ytapi"
+	"github.com/denisb0/youtube-experiments/ytclient"
+)
+
+// archiveVideoParts is deliberately richer than ytapi's default upload
+// hydration parts: an archive is meant to be a durable snapshot, so it
+// also pulls topicDetails and recordingDetails.
+var archiveVideoParts = []string{"snippet", "contentDetails", "statistics", "topicDetails", "recordingDetails"}
+
+var (
+	channelIDPattern = regexp.MustCompile(`^UC[0-9A-Za-z_-]{22}$`)
+	channelURLRe     = regexp.MustCompile(`youtube\.com/channel/(UC[0-9A-Za-z_-]{22})`)
+	userURLRe        = regexp.MustCompile(`youtube\.com/user/([0-9A-Za-z_.-]+)`)
+	handleRe         = regexp.MustCompile(`(?:youtube\.com/)?@([0-9A-Za-z_.-]+)`)
+)
+
+// ArchiveChannel resolves input — a raw channel ID, an "@handle", a
+// legacy "/user/<name>" URL, or a full youtube.com/channel/... or
+// /@handle/shorts URL — to a channel, fetches every one of its uploaded
+// videos, and writes outDir/<channelID>/<videoID>.json for each video
+// plus an outDir/<channelID>/channel.json summary.
+func ArchiveChannel(ctx context.Context, c *ytclient.Client, input string, outDir string) error {
+	channelID, err := resolveChannelID(ctx, c, input)
+	if err != nil {
+		return fmt.Errorf("resolving channel %q: %w", input, err)
+	}
+
+	channelDir := filepath.Join(outDir, channelID)
+	if err := os.MkdirAll(channelDir, 0o755); err != nil {
+		return err
+	}
+
+	channelResp, err := ytapi.Channels(ctx, c, channelID, []string{"snippet", "statistics", "contentDetails"})
+	if err != nil {
+		return fmt.Errorf("fetching channel %s: %w", channelID, err)
+	}
+	if len(channelResp.Items) == 0 {
+		return fmt.Errorf("channel %s not found", channelID)
+	}
+	if err := writeJSON(filepath.Join(channelDir, "channel.json"), channelResp.Items[0]); err != nil {
+		return err
+	}
+
+	return archiveVideos(ctx, c, channelID, channelDir)
+}
+
+// archiveVideos walks the channel's uploads playlist directly, rather
+// than through ytapi.IterateUploads, because an archive needs parts
+// (topicDetails, recordingDetails) beyond that function's defaults.
+func archiveVideos(ctx context.Context, c *ytclient.Client, channelID, channelDir string) error {
+	playlistID, err := ytapi.UploadsPlaylistID(ctx, c, channelID)
+	if err != nil {
+		return fmt.Errorf("resolving uploads playlist: %w", err)
+	}
+
+	pageToken := ""
+	written := 0
+	for {
+		page, err := ytapi.PlaylistItems(ctx, c, playlistID, []string{"contentDetails"}, pageToken)
+		if err != nil {
+			return fmt.Errorf("listing uploads: %w", err)
+		}
+
+		ids := make([]string, 0, len(page.Items))
+		for _, item := range page.Items {
+			ids = append(ids, item.ContentDetails.VideoId)
+		}
+
+		videos, err := ytapi.HydrateVideos(ctx, c, ids, archiveVideoParts)
+		if err != nil {
+			return fmt.Errorf("hydrating videos: %w", err)
+		}
+		for _, v := range videos {
+			if err := writeJSON(filepath.Join(channelDir, v.Id+".json"), v); err != nil {
+				return err
+			}
+			written++
+		}
+
+		if page.NextPageToken == "" {
+			break
+		}
+		pageToken = page.NextPageToken
+	}
+
+	fmt.Printf("archived %d videos for channel %s to %s\n", written, channelID, channelDir)
+	return nil
+}
+
+func writeJSON(path string, v interface{}) error {
+	j, err := json.MarshalIndent(v, "", "   ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, j, 0o644)
+}
+
+// resolveChannelID accepts a raw channel ID, an "@handle", a legacy
+// "/user/<name>" URL, or a full channel/handle URL, and resolves it to a
+// canonical channel ID. Anything that doesn't match one of those shapes
+// falls back to a free-text channel search.
+func resolveChannelID(ctx context.Context, c *ytclient.Client, input string) (string, error) {
+	input = strings.TrimSpace(input)
+
+	switch {
+	case channelIDPattern.MatchString(input):
+		return input, nil
+	case channelURLRe.MatchString(input):
+		return channelURLRe.FindStringSubmatch(input)[1], nil
+	case userURLRe.MatchString(input):
+		return ytapi.ChannelByUsername(ctx, c, userURLRe.FindStringSubmatch(input)[1])
+	case handleRe.MatchString(input):
+		return ytapi.ChannelByHandle(ctx, c, handleRe.FindStringSubmatch(input)[1])
+	default:
+		return ytapi.ResolveChannel(ctx, c, input)
+	}
+}
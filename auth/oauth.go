@@ -0,0 +1,136 @@
+// Package auth handles the OAuth2 user-consent flow needed for YouTube
+// endpoints that an API key alone can't reach (uploads, playlist
+// mutation, "mine=true" queries). Tokens are cached on disk so the
+// consent screen only has to be completed once per scope set.
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+const cacheDirName = ".youtube-experiments"
+
+// TokenSource returns an oauth2.TokenSource authorized for scopes, reading
+// the OAuth2 client secrets from clientSecretsPath. It loads a cached,
+// refreshable token from disk if one exists for this exact scope set;
+// otherwise it runs the interactive consent flow and caches the result.
+func TokenSource(ctx context.Context, clientSecretsPath string, scopes ...string) (oauth2.TokenSource, error) {
+	b, err := os.ReadFile(clientSecretsPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading client secrets: %w", err)
+	}
+	config, err := google.ConfigFromJSON(b, scopes...)
+	if err != nil {
+		return nil, fmt.Errorf("parsing client secrets: %w", err)
+	}
+
+	tokenPath, err := cachePath(scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := loadToken(tokenPath)
+	if err != nil {
+		token, err = tokenFromWeb(ctx, config)
+		if err != nil {
+			return nil, fmt.Errorf("running oauth consent flow: %w", err)
+		}
+		if err := saveToken(tokenPath, token); err != nil {
+			return nil, fmt.Errorf("caching oauth token: %w", err)
+		}
+	}
+
+	return config.TokenSource(ctx, token), nil
+}
+
+// cachePath returns where the token for this exact scope set is cached,
+// creating the parent directory if needed. Different scope sets get
+// different files so a "readonly" token never gets mistaken for one with
+// upload access.
+func cachePath(scopes []string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("resolving home directory: %w", err)
+	}
+	dir := filepath.Join(home, cacheDirName)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("creating token cache dir: %w", err)
+	}
+	hash := sha256.Sum256([]byte(strings.Join(scopes, " ")))
+	return filepath.Join(dir, fmt.Sprintf("token-%s.json", hex.EncodeToString(hash[:])[:16])), nil
+}
+
+func loadToken(path string) (*oauth2.Token, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	token := &oauth2.Token{}
+	if err := json.NewDecoder(f).Decode(token); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+func saveToken(path string, token *oauth2.Token) error {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return json.NewEncoder(f).Encode(token)
+}
+
+// tokenFromWeb runs the standard installed-app OAuth2 flow: it spins up a
+// loopback HTTP listener to catch the redirect, prints the consent URL
+// for the user to open, and exchanges the returned code for a token.
+func tokenFromWeb(ctx context.Context, config *oauth2.Config) (*oauth2.Token, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("starting loopback listener: %w", err)
+	}
+	defer listener.Close()
+
+	config.RedirectURL = fmt.Sprintf("http://127.0.0.1:%d", listener.Addr().(*net.TCPAddr).Port)
+
+	codeCh := make(chan string, 1)
+	errCh := make(chan error, 1)
+	server := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			code := r.URL.Query().Get("code")
+			if code == "" {
+				http.Error(w, "missing code", http.StatusBadRequest)
+				errCh <- fmt.Errorf("no code in oauth redirect: %s", r.URL.RawQuery)
+				return
+			}
+			fmt.Fprintln(w, "Authorization complete, you can close this tab.")
+			codeCh <- code
+		}),
+	}
+	go server.Serve(listener)
+	defer server.Close()
+
+	authURL := config.AuthCodeURL("state", oauth2.AccessTypeOffline)
+	fmt.Printf("Open the following URL in your browser to authorize:\n%v\n", authURL)
+
+	select {
+	case code := <-codeCh:
+		return config.Exchange(ctx, code)
+	case err := <-errCh:
+		return nil, err
+	}
+}
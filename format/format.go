@@ -0,0 +1,83 @@
+// Package format renders YouTube API types as compact, human-friendly
+// text instead of raw JSON.
+package format
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"google.golang.org/api/youtube/v3"
+)
+
+// iso8601DurationPattern matches the duration forms contentDetails.duration
+// actually returns: "PT#H#M#S" for ordinary videos, and the rarer
+// "P#DT#H#M#S" for videos or livestreams that run a day or longer.
+var iso8601DurationPattern = regexp.MustCompile(`^P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// parseISO8601Duration parses an ISO-8601 duration string as returned in
+// contentDetails.duration.
+func parseISO8601Duration(s string) (time.Duration, error) {
+	m := iso8601DurationPattern.FindStringSubmatch(s)
+	if m == nil {
+		return 0, fmt.Errorf("format: invalid ISO-8601 duration %q", s)
+	}
+
+	var d time.Duration
+	for i, unit := range []time.Duration{24 * time.Hour, time.Hour, time.Minute, time.Second} {
+		if m[i+1] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(m[i+1])
+		if err != nil {
+			return 0, fmt.Errorf("format: invalid ISO-8601 duration %q: %w", s, err)
+		}
+		d += time.Duration(n) * unit
+	}
+	return d, nil
+}
+
+// formatCount renders n with a K/M suffix once it's large enough that the
+// exact count is more noise than signal, matching how view/like counts are
+// normally displayed on YouTube itself.
+func formatCount(n uint64, label string) string {
+	switch {
+	case n >= 1_000_000:
+		return fmt.Sprintf("%.1fM %s", float64(n)/1_000_000, label)
+	case n >= 1_000:
+		return fmt.Sprintf("%.0fK %s", float64(n)/1_000, label)
+	default:
+		return fmt.Sprintf("%s %s", humanize.Comma(int64(n)), label)
+	}
+}
+
+// FormatVideo renders v as a single human-friendly summary line, e.g.
+// "Title — ChannelName [4m32s] 1.2M views, 34K likes, published 3 months ago".
+func FormatVideo(v *youtube.Video) string {
+	parts := []string{fmt.Sprintf("%s — %s", v.Snippet.Title, v.Snippet.ChannelTitle)}
+
+	if v.ContentDetails != nil {
+		if d, err := parseISO8601Duration(v.ContentDetails.Duration); err == nil {
+			parts = append(parts, fmt.Sprintf("[%s]", d))
+		}
+	}
+
+	var stats []string
+	if v.Statistics != nil {
+		stats = append(stats, formatCount(v.Statistics.ViewCount, "views"))
+		stats = append(stats, formatCount(v.Statistics.LikeCount, "likes"))
+	}
+	if v.Snippet.PublishedAt != "" {
+		if t, err := time.Parse(time.RFC3339, v.Snippet.PublishedAt); err == nil {
+			stats = append(stats, "published "+humanize.Time(t))
+		}
+	}
+	if len(stats) > 0 {
+		parts = append(parts, strings.Join(stats, ", "))
+	}
+
+	return strings.Join(parts, " ")
+}
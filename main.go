@@ -3,21 +3,59 @@ package main
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"flag"
 	"fmt"
 	"log"
 	"os"
+	"strings"
 
 	"github.com/joho/godotenv"
 	"google.golang.org/api/option"
 	"google.golang.org/api/youtube/v3"
+
+	"github.com/denisb0/youtube-experiments/archive"
+	"github.com/denisb0/youtube-experiments/auth"
+	"github.com/denisb0/youtube-experiments/format"
+	"github.com/denisb0/youtube-experiments/ytapi"
+	"github.com/denisb0/youtube-experiments/ytclient"
 )
 
 var (
 	query      = flag.String("query", "Google", "Search term")
 	id         = flag.String("id", "", "Video/channel/playlist id")
 	maxResults = flag.Int64("max-results", 5, "Max YouTube results")
+
+	authMode          = flag.String("auth", authModeAPIKey, "Auth mode: apikey or oauth")
+	clientSecretsPath = flag.String("client-secrets", "client_secrets.json", "Path to OAuth2 client secrets JSON (-auth=oauth only)")
+
+	cmd = flag.String("cmd", "", "Subcommand to run, e.g. \"upload\" or \"archive\"")
+
+	uploadFilename    = flag.String("filename", "", "Path to the video file to upload (cmd=upload only)")
+	uploadTitle       = flag.String("title", "", "Video title (cmd=upload only)")
+	uploadDescription = flag.String("description", "", "Video description (cmd=upload only)")
+	uploadCategory    = flag.String("category", "22", "YouTube video category ID (cmd=upload only)")
+	uploadKeywords    = flag.String("keywords", "", "Comma-separated video tags (cmd=upload only)")
+	uploadPrivacy     = flag.String("privacy", "private", "Privacy status: public, unlisted, or private (cmd=upload only)")
+
+	archiveOutDir = flag.String("out-dir", "archive", "Directory to write archived channel data to (cmd=archive only)")
+
+	outputFormat = flag.String("format", formatHuman, "Output format for videoDetails: human or json")
+
+	quotaBudget = flag.Int64("quota-budget", 10000, "Daily YouTube Data API quota budget to enforce; 0 means unlimited")
+)
+
+// Auth modes accepted by -auth. apikey covers read-only public endpoints;
+// oauth is required for anything that needs user consent, such as
+// uploads or "mine=true" queries.
+const (
+	authModeAPIKey = "apikey"
+	authModeOAuth  = "oauth"
+)
+
+// Output formats accepted by -format.
+const (
+	formatHuman = "human"
+	formatJSON  = "json"
 )
 
 func handleError(err error, message string) {
@@ -43,12 +81,8 @@ func printIDs(sectionName string, matches map[string]string) {
 	fmt.Printf("\n\n")
 }
 
-func search(s *youtube.Service, query string, maxResults int64) error {
-	// Make the API call to YouTube.
-	call := s.Search.List([]string{"id", "snippet"}).
-		Q(query).
-		MaxResults(maxResults)
-	response, err := call.Do()
+func search(ctx context.Context, c *ytclient.Client, query string, maxResults int64) error {
+	response, err := ytapi.Search(ctx, c, query, maxResults)
 	if err != nil {
 		return err
 	}
@@ -76,20 +110,17 @@ func search(s *youtube.Service, query string, maxResults int64) error {
 	return nil
 }
 
-func videoDetails(s *youtube.Service, id string, parts []string) error {
-	// Make the API call to YouTube.
+func videoDetails(ctx context.Context, c *ytclient.Client, id string, parts []string, outputFormat string) error {
 	fmt.Printf("get video %s, parts %v\n", id, parts)
-	call := s.Videos.List(parts).Id(id)
-	response, err := call.Do()
+	v, err := ytapi.VideoDetails(ctx, c, id, parts)
 	if err != nil {
 		return err
 	}
-	if len(response.Items) == 0 {
-		fmt.Printf("%+v\n", response)
-		return errors.New("no content found")
-	}
 
-	v := response.Items[0]
+	if outputFormat == formatHuman {
+		fmt.Println(format.FormatVideo(v))
+		return nil
+	}
 
 	j, err := json.MarshalIndent(v, "", "   ")
 	if err != nil {
@@ -101,20 +132,12 @@ func videoDetails(s *youtube.Service, id string, parts []string) error {
 	return nil
 }
 
-func playlistItems(s *youtube.Service, id string, parts []string, pageToken string) error {
+func playlistItems(ctx context.Context, c *ytclient.Client, id string, parts []string, pageToken string) error {
 	fmt.Printf("get video %s, parts %v\n", id, parts)
-	call := s.PlaylistItems.List(parts).PlaylistId(id).MaxResults(3).Fields("items/snippet/title", "items/snippet/resourceId/videoId")
-	if pageToken != "" {
-		call = call.PageToken(pageToken)
-	}
-	response, err := call.Do()
+	response, err := ytapi.PlaylistItems(ctx, c, id, parts, pageToken)
 	if err != nil {
 		return err
 	}
-	if len(response.Items) == 0 {
-		fmt.Printf("%+v\n", response)
-		return errors.New("no content found")
-	}
 
 	j, err := json.MarshalIndent(response, "", "   ")
 	if err != nil {
@@ -126,18 +149,12 @@ func playlistItems(s *youtube.Service, id string, parts []string, pageToken stri
 	return nil
 }
 
-func playlists(s *youtube.Service, id string, parts []string) error {
+func playlists(ctx context.Context, c *ytclient.Client, id string, parts []string) error {
 	fmt.Printf("get playlist %s, parts %v\n", id, parts)
-	call := s.Playlists.List(parts).ChannelId(id).MaxResults(50).Fields("items/snippet/title", "items/id")
-
-	response, err := call.Do()
+	response, err := ytapi.Playlists(ctx, c, id, parts)
 	if err != nil {
 		return err
 	}
-	if len(response.Items) == 0 {
-		fmt.Printf("%+v\n", response)
-		return errors.New("no content found")
-	}
 
 	j, err := json.MarshalIndent(response, "", "   ")
 	if err != nil {
@@ -149,18 +166,12 @@ func playlists(s *youtube.Service, id string, parts []string) error {
 	return nil
 }
 
-func channels(s *youtube.Service, id string, parts []string) error {
+func channels(ctx context.Context, c *ytclient.Client, id string, parts []string) error {
 	fmt.Printf("get channels %s, parts %v\n", id, parts)
-	call := s.Channels.List(parts).Id(id)
-
-	response, err := call.Do()
+	response, err := ytapi.Channels(ctx, c, id, parts)
 	if err != nil {
 		return err
 	}
-	if len(response.Items) == 0 {
-		fmt.Printf("%+v\n", response)
-		return errors.New("no content found")
-	}
 
 	j, err := json.MarshalIndent(response, "", "   ")
 	if err != nil {
@@ -172,73 +183,94 @@ func channels(s *youtube.Service, id string, parts []string) error {
 	return nil
 }
 
-func getChannelID(s *youtube.Service, channelName string) (string, error) {
-	fmt.Printf("get channels %s\n", channelName)
+const (
+	cmdUpload  = "upload"
+	cmdArchive = "archive"
+)
 
-	call := s.Search.List([]string{"id"}).
-		Q(channelName).
-		MaxResults(1).Type("channel")
-	response, err := call.Do()
+func upload(ctx context.Context, c *ytclient.Client) error {
+	file, err := os.Open(*uploadFilename)
 	if err != nil {
-		return "", err
+		return err
 	}
+	defer file.Close()
 
-	if len(response.Items) == 0 {
-		return "", errors.New("no result found")
+	params := ytapi.UploadParams{
+		Title:       *uploadTitle,
+		Description: *uploadDescription,
+		CategoryID:  *uploadCategory,
+		Privacy:     *uploadPrivacy,
 	}
-	item := response.Items[0]
-	if item.Id.Kind != "youtube#channel" {
-		return "", errors.New("result not channel type")
+	if *uploadKeywords != "" {
+		params.Keywords = strings.Split(*uploadKeywords, ",")
 	}
 
-	j, err := json.MarshalIndent(response, "", "   ")
+	video, err := ytapi.UploadVideo(ctx, c, params, file)
 	if err != nil {
-		return "", err
+		return err
 	}
-	fmt.Println(string(j))
 
-	return item.Id.ChannelId, nil
-}
-
-func getUploadsPlaylistID(s *youtube.Service, channelID string) (string, error) {
-	call := s.Channels.List([]string{"contentDetails"}).MaxResults(1).Id(channelID).Fields("items/contentDetails/relatedPlaylists/uploads")
-
-	response, err := call.Do()
-	if err != nil {
-		return "", err
-	}
-	return response.Items[0].ContentDetails.RelatedPlaylists.Uploads, nil
+	fmt.Println(video.Id)
+	return nil
 }
 
 func main() {
 	flag.Parse()
-	err := godotenv.Load()
-	if err != nil {
-		log.Fatal("Error loading .env file")
+	ctx := context.Background()
+
+	var clientOpt option.ClientOption
+	switch *authMode {
+	case authModeOAuth:
+		ts, err := auth.TokenSource(ctx, *clientSecretsPath, youtube.YoutubeReadonlyScope, youtube.YoutubeUploadScope)
+		handleError(err, "setting up oauth2 token source")
+		clientOpt = option.WithTokenSource(ts)
+	case authModeAPIKey:
+		if err := godotenv.Load(); err != nil {
+			log.Fatal("Error loading .env file")
+		}
+		clientOpt = option.WithAPIKey(os.Getenv(apiKeyEnv))
+	default:
+		log.Fatalf("unknown -auth mode %q, want %q or %q", *authMode, authModeAPIKey, authModeOAuth)
 	}
-	apiKey := os.Getenv(apiKeyEnv)
 
-	ctx := context.Background()
-	service, err := youtube.NewService(ctx, option.WithAPIKey(apiKey))
+	service, err := youtube.NewService(ctx, clientOpt)
 	if err != nil {
 		log.Fatalf("Error creating new YouTube client: %v", err)
 	}
+	client := ytclient.New(service, *quotaBudget)
+	defer printStats(client)
+
+	switch *cmd {
+	case cmdUpload:
+		handleError(upload(ctx, client), "upload")
+		return
+	case cmdArchive:
+		handleError(archive.ArchiveChannel(ctx, client, *id, *archiveOutDir), "archive")
+		return
+	}
 
-	// handleError(videoDetails(service, *videoID, []string{"snippet", "player", "topicDetails", "recordingDetails"}), "show video details")
-	// handleError(playlistItems(service, *id, []string{"snippet"}, ""), "show playlist")
-	// handleError(playlists(service, *id, []string{"snippet"}), "show playlists")
-	// handleError(channels(service, *id, []string{"contentDetails"}), "show playlists")
+	// handleError(videoDetails(ctx, client, *videoID, []string{"snippet", "player", "topicDetails", "recordingDetails"}, *outputFormat), "show video details")
+	// handleError(playlistItems(ctx, client, *id, []string{"snippet"}, ""), "show playlist")
+	// handleError(playlists(ctx, client, *id, []string{"snippet"}), "show playlists")
+	// handleError(channels(ctx, client, *id, []string{"contentDetails"}), "show playlists")
 
-	// uploads, err := getUploadsPlaylistID(service, *id)
-	// handleError(err, "getUploadsPlaylistID")
+	// uploads, err := ytapi.UploadsPlaylistID(ctx, client, *id)
+	// handleError(err, "UploadsPlaylistID")
 	// fmt.Println(uploads)
-	// handleError(playlistItems(service, uploads, []string{"snippet"}, ""), "show playlist")
+	// handleError(playlistItems(ctx, client, uploads, []string{"snippet"}, ""), "show playlist")
 
-	chID, err := getChannelID(service, *id)
-	handleError(err, "getChannelID")
+	chID, err := ytapi.ResolveChannel(ctx, client, *id)
+	handleError(err, "ResolveChannel")
 	fmt.Println(chID)
 }
 
+// printStats reports the estimated quota consumed this run, so a budget
+// that's too low (or too generous) shows up immediately.
+func printStats(c *ytclient.Client) {
+	stats := c.Stats()
+	fmt.Printf("quota used: %d/%d\n", stats.Spent, stats.Budget)
+}
+
 // my channel UC64mIIOlYMWB5ac6VoaRj8w
 // dailydev UCXUjtTfQWJa0G9K_SqRm3jQ
 // https://www.youtube.com/@dailydotdev/shorts
@@ -0,0 +1,97 @@
+// Package ytapi wraps the raw YouTube Data API v3 calls used throughout
+// this repo behind a small set of typed, pagination-aware functions. All
+// calls are routed through a ytclient.Client for quota tracking, rate
+// limiting, and retries; UploadVideo is the exception for retries, see its
+// doc.
+package ytapi
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/api/youtube/v3"
+
+	"github.com/denisb0/youtube-experiments/ytclient"
+)
+
+// Channels wraps channels.list for the given channel ID and parts.
+func Channels(ctx context.Context, c *ytclient.Client, id string, parts []string) (*youtube.ChannelListResponse, error) {
+	return ytclient.Call(ctx, c, "channels.list", ytclient.CostList, func() (*youtube.ChannelListResponse, error) {
+		return c.Service.Channels.List(parts).Id(id).Context(ctx).Do()
+	})
+}
+
+// UploadsPlaylistID returns the ID of the "uploads" playlist associated
+// with channelID, i.e. the playlist that contains every video the channel
+// has published.
+func UploadsPlaylistID(ctx context.Context, c *ytclient.Client, channelID string) (string, error) {
+	response, err := ytclient.Call(ctx, c, "channels.list", ytclient.CostList, func() (*youtube.ChannelListResponse, error) {
+		return c.Service.Channels.List([]string{"contentDetails"}).
+			MaxResults(1).
+			Id(channelID).
+			Fields("items/contentDetails/relatedPlaylists/uploads").
+			Context(ctx).
+			Do()
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(response.Items) == 0 {
+		return "", errors.New("no channel found for id")
+	}
+	return response.Items[0].ContentDetails.RelatedPlaylists.Uploads, nil
+}
+
+// ResolveChannel resolves a free-text channel name or query to a channel
+// ID via search.list. It only matches results of kind "youtube#channel".
+func ResolveChannel(ctx context.Context, c *ytclient.Client, name string) (string, error) {
+	response, err := ytclient.Call(ctx, c, "search.list", ytclient.CostSearch, func() (*youtube.SearchListResponse, error) {
+		return c.Service.Search.List([]string{"id"}).
+			Q(name).
+			MaxResults(1).
+			Type("channel").
+			Context(ctx).
+			Do()
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(response.Items) == 0 {
+		return "", errors.New("no result found")
+	}
+	item := response.Items[0]
+	if item.Id.Kind != "youtube#channel" {
+		return "", errors.New("result not channel type")
+	}
+	return item.Id.ChannelId, nil
+}
+
+// ChannelByHandle resolves a channel's "@handle" (without the "@") to its
+// channel ID via channels.list?forHandle=.
+func ChannelByHandle(ctx context.Context, c *ytclient.Client, handle string) (string, error) {
+	response, err := ytclient.Call(ctx, c, "channels.list", ytclient.CostList, func() (*youtube.ChannelListResponse, error) {
+		return c.Service.Channels.List([]string{"id"}).ForHandle("@" + handle).Context(ctx).Do()
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(response.Items) == 0 {
+		return "", errors.New("no channel found for handle")
+	}
+	return response.Items[0].Id, nil
+}
+
+// ChannelByUsername resolves a legacy "/user/<name>" username to its
+// channel ID via channels.list?forUsername=.
+func ChannelByUsername(ctx context.Context, c *ytclient.Client, username string) (string, error) {
+	response, err := ytclient.Call(ctx, c, "channels.list", ytclient.CostList, func() (*youtube.ChannelListResponse, error) {
+		return c.Service.Channels.List([]string{"id"}).ForUsername(username).Context(ctx).Do()
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(response.Items) == 0 {
+		return "", errors.New("no channel found for username")
+	}
+	return response.Items[0].Id, nil
+}
@@ -0,0 +1,35 @@
+package ytapi
+
+import (
+	"context"
+
+	"google.golang.org/api/youtube/v3"
+
+	"github.com/denisb0/youtube-experiments/ytclient"
+)
+
+// PlaylistItems wraps playlistItems.list for a single page of id's items.
+// Pass pageToken "" to fetch the first page.
+func PlaylistItems(ctx context.Context, c *ytclient.Client, id string, parts []string, pageToken string) (*youtube.PlaylistItemListResponse, error) {
+	return ytclient.Call(ctx, c, "playlistItems.list", ytclient.CostList, func() (*youtube.PlaylistItemListResponse, error) {
+		call := c.Service.PlaylistItems.List(parts).
+			PlaylistId(id).
+			MaxResults(50).
+			Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		return call.Do()
+	})
+}
+
+// Playlists wraps playlists.list for the playlists owned by channelID.
+func Playlists(ctx context.Context, c *ytclient.Client, channelID string, parts []string) (*youtube.PlaylistListResponse, error) {
+	return ytclient.Call(ctx, c, "playlists.list", ytclient.CostList, func() (*youtube.PlaylistListResponse, error) {
+		return c.Service.Playlists.List(parts).
+			ChannelId(channelID).
+			MaxResults(50).
+			Context(ctx).
+			Do()
+	})
+}
@@ -0,0 +1,21 @@
+package ytapi
+
+import (
+	"context"
+
+	"google.golang.org/api/youtube/v3"
+
+	"github.com/denisb0/youtube-experiments/ytclient"
+)
+
+// Search wraps search.list for query, returning at most maxResults items
+// across videos, channels, and playlists combined.
+func Search(ctx context.Context, c *ytclient.Client, query string, maxResults int64) (*youtube.SearchListResponse, error) {
+	return ytclient.Call(ctx, c, "search.list", ytclient.CostSearch, func() (*youtube.SearchListResponse, error) {
+		return c.Service.Search.List([]string{"id", "snippet"}).
+			Q(query).
+			MaxResults(maxResults).
+			Context(ctx).
+			Do()
+	})
+}
@@ -0,0 +1,59 @@
+package ytapi
+
+import (
+	"context"
+	"io"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/youtube/v3"
+
+	"github.com/denisb0/youtube-experiments/ytclient"
+)
+
+// uploadChunkSize is the chunk size passed to the resumable upload
+// protocol; videos.insert retries failed chunks instead of restarting
+// the whole upload.
+const uploadChunkSize = 8 << 20 // 8 MiB
+
+// UploadParams describes the metadata for a new video upload.
+type UploadParams struct {
+	Title       string
+	Description string
+	CategoryID  string
+	Keywords    []string
+	// Privacy is one of "public", "unlisted", or "private".
+	Privacy string
+}
+
+// UploadVideo wraps videos.insert, streaming media as a resumable upload
+// so transient network failures retry the current chunk instead of
+// restarting the whole upload. It requires a service authorized with the
+// youtube.upload scope (see the auth package).
+//
+// Unlike the other ytapi functions, UploadVideo only reserves quota and a
+// rate limiter slot through c; it doesn't route through ytclient.Call's
+// retry loop, since the resumable upload protocol already retries failed
+// chunks and a whole-call retry would try to re-read an already-consumed
+// media reader.
+func UploadVideo(ctx context.Context, c *ytclient.Client, params UploadParams, media io.Reader) (*youtube.Video, error) {
+	if err := ytclient.Reserve(ctx, c, "videos.insert", ytclient.CostInsert); err != nil {
+		return nil, err
+	}
+
+	video := &youtube.Video{
+		Snippet: &youtube.VideoSnippet{
+			Title:       params.Title,
+			Description: params.Description,
+			CategoryId:  params.CategoryID,
+			Tags:        params.Keywords,
+		},
+		Status: &youtube.VideoStatus{
+			PrivacyStatus: params.Privacy,
+		},
+	}
+
+	call := c.Service.Videos.Insert([]string{"snippet", "status"}, video).
+		Media(media, googleapi.ChunkSize(uploadChunkSize)).
+		Context(ctx)
+	return call.Do()
+}
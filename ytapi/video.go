@@ -0,0 +1,114 @@
+package ytapi
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"google.golang.org/api/youtube/v3"
+
+	"github.com/denisb0/youtube-experiments/ytclient"
+)
+
+// videoHydrateParts are the parts fetched whenever a video is hydrated
+// from an uploads playlist page; callers needing more (e.g. topicDetails)
+// should call HydrateVideos directly with their own parts.
+var videoHydrateParts = []string{"contentDetails", "snippet", "statistics"}
+
+// VideoDetails wraps videos.list for a single video ID.
+func VideoDetails(ctx context.Context, c *ytclient.Client, id string, parts []string) (*youtube.Video, error) {
+	response, err := ytclient.Call(ctx, c, "videos.list", ytclient.CostList, func() (*youtube.VideoListResponse, error) {
+		return c.Service.Videos.List(parts).Id(id).Context(ctx).Do()
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(response.Items) == 0 {
+		return nil, errors.New("no content found")
+	}
+	return response.Items[0], nil
+}
+
+// HydrateVideos batch-fetches up to 50 video IDs in a single videos.list
+// call, which is the API's per-request limit, with the given parts.
+func HydrateVideos(ctx context.Context, c *ytclient.Client, ids []string, parts []string) ([]*youtube.Video, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	response, err := ytclient.Call(ctx, c, "videos.list", ytclient.CostList, func() (*youtube.VideoListResponse, error) {
+		return c.Service.Videos.List(parts).Id(strings.Join(ids, ",")).Context(ctx).Do()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response.Items, nil
+}
+
+// hydrateVideos batch-fetches videos with the default parts used by
+// IterateUploads and VideosInChannel.
+func hydrateVideos(ctx context.Context, c *ytclient.Client, ids []string) ([]*youtube.Video, error) {
+	return HydrateVideos(ctx, c, ids, videoHydrateParts)
+}
+
+func byPublishedAt(videos []*youtube.Video) {
+	sort.Slice(videos, func(i, j int) bool {
+		return videos[i].Snippet.PublishedAt < videos[j].Snippet.PublishedAt
+	})
+}
+
+// IterateUploads walks channelID's uploads playlist page by page, batch
+// hydrating 50 video IDs at a time, and calls yield once per video in
+// publishedAt order within each batch. It stops early if yield returns
+// false.
+func IterateUploads(ctx context.Context, c *ytclient.Client, channelID string, yield func(*youtube.Video) bool) error {
+	playlistID, err := UploadsPlaylistID(ctx, c, channelID)
+	if err != nil {
+		return err
+	}
+
+	pageToken := ""
+	for {
+		page, err := PlaylistItems(ctx, c, playlistID, []string{"contentDetails"}, pageToken)
+		if err != nil {
+			return err
+		}
+
+		ids := make([]string, 0, len(page.Items))
+		for _, item := range page.Items {
+			ids = append(ids, item.ContentDetails.VideoId)
+		}
+
+		videos, err := hydrateVideos(ctx, c, ids)
+		if err != nil {
+			return err
+		}
+		byPublishedAt(videos)
+
+		for _, v := range videos {
+			if !yield(v) {
+				return nil
+			}
+		}
+
+		if page.NextPageToken == "" {
+			return nil
+		}
+		pageToken = page.NextPageToken
+	}
+}
+
+// VideosInChannel fetches every video uploaded by channelID, sorted by
+// publishedAt.
+func VideosInChannel(ctx context.Context, c *ytclient.Client, channelID string) ([]*youtube.Video, error) {
+	var videos []*youtube.Video
+	err := IterateUploads(ctx, c, channelID, func(v *youtube.Video) bool {
+		videos = append(videos, v)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	byPublishedAt(videos)
+	return videos, nil
+}
@@ -0,0 +1,194 @@
+// Package ytclient wraps a *youtube.Service with the reliability layer
+// every call in this repo needs: a per-day quota budget, a token-bucket
+// rate limiter, and retry with exponential backoff on transient errors.
+package ytclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/youtube/v3"
+)
+
+// Estimated quota cost per call, in the units YouTube's quota system
+// uses. search.list is disproportionately expensive; most list endpoints
+// cost a flat 1 unit regardless of parts requested.
+const (
+	CostSearch = 100
+	CostList   = 1
+	CostInsert = 1600
+)
+
+const (
+	defaultRatePerSecond = 50
+	defaultMaxAttempts   = 5
+	defaultBaseBackoff   = 500 * time.Millisecond
+	defaultMaxBackoff    = 30 * time.Second
+)
+
+// Client wraps service with quota tracking, rate limiting, and retries.
+// Build all API calls through Call rather than calling service directly.
+type Client struct {
+	Service *youtube.Service
+
+	limiter     *rate.Limiter
+	maxAttempts int
+	baseBackoff time.Duration
+	maxBackoff  time.Duration
+
+	mu     sync.Mutex
+	budget int64
+	spent  int64
+}
+
+// New wraps service with a daily quota budget of budget units. A budget
+// of 0 means unlimited.
+func New(service *youtube.Service, budget int64) *Client {
+	return &Client{
+		Service:     service,
+		limiter:     rate.NewLimiter(rate.Limit(defaultRatePerSecond), defaultRatePerSecond),
+		maxAttempts: defaultMaxAttempts,
+		baseBackoff: defaultBaseBackoff,
+		maxBackoff:  defaultMaxBackoff,
+		budget:      budget,
+	}
+}
+
+// Stats reports quota consumed so far and the configured budget.
+type Stats struct {
+	Spent  int64
+	Budget int64
+}
+
+func (c *Client) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return Stats{Spent: c.spent, Budget: c.budget}
+}
+
+// reserve blocks for the rate limiter's token and then accounts for
+// cost against the daily budget, refusing the call if it would exceed
+// it.
+func (c *Client) reserve(ctx context.Context, endpoint string, cost int64) error {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.budget > 0 && c.spent+cost > c.budget {
+		return fmt.Errorf("ytclient: quota budget of %d exceeded (spent %d, %s needs %d)", c.budget, c.spent, endpoint, cost)
+	}
+	c.spent += cost
+	return nil
+}
+
+// Reserve accounts for a single call to endpoint at the given quota cost
+// and blocks for a rate limiter slot, without the retry loop Call adds.
+// Use this for calls that already manage their own retries, such as a
+// resumable upload, where blindly retrying from Call would re-read
+// already-consumed request bodies.
+func Reserve(ctx context.Context, c *Client, endpoint string, cost int64) error {
+	return c.reserve(ctx, endpoint, cost)
+}
+
+// Call runs do, which should issue exactly one API request to endpoint at
+// an estimated cost of cost quota units. It reserves quota and a rate
+// limiter slot up front, then retries do with exponential backoff and
+// jitter on rate-limit and server errors. A 403 with reason "keyInvalid"
+// fails immediately rather than retrying, since retrying can't fix it.
+func Call[T any](ctx context.Context, c *Client, endpoint string, cost int64, do func() (T, error)) (T, error) {
+	var zero T
+
+	if err := c.reserve(ctx, endpoint, cost); err != nil {
+		return zero, err
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < c.maxAttempts; attempt++ {
+		if attempt > 0 {
+			if err := sleepBackoff(ctx, c.backoff(attempt)); err != nil {
+				return zero, err
+			}
+		}
+
+		result, err := do()
+		if err == nil {
+			return result, nil
+		}
+
+		if invalid := keyInvalidError(err); invalid != nil {
+			return zero, invalid
+		}
+		if !isRetryable(err) {
+			return zero, err
+		}
+		lastErr = err
+	}
+
+	return zero, fmt.Errorf("ytclient: %s failed after %d attempts: %w", endpoint, c.maxAttempts, lastErr)
+}
+
+func (c *Client) backoff(attempt int) time.Duration {
+	d := c.baseBackoff * time.Duration(1<<uint(attempt-1))
+	if d > c.maxBackoff {
+		d = c.maxBackoff
+	}
+	// Full jitter: spreads retries from concurrent callers instead of
+	// having them all wake up and collide on the same instant.
+	return time.Duration(rand.Int63n(int64(d)))
+}
+
+func sleepBackoff(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func isRetryable(err error) bool {
+	var gerr *googleapi.Error
+	if !errors.As(err, &gerr) {
+		return false
+	}
+	switch {
+	case gerr.Code == 429:
+		return true
+	case gerr.Code == 403:
+		return hasReason(gerr, "quotaExceeded", "userRateLimitExceeded")
+	case gerr.Code >= 500:
+		return true
+	}
+	return false
+}
+
+// keyInvalidError returns a clear, wrapped error if err is a 403 with
+// reason "keyInvalid", or nil otherwise.
+func keyInvalidError(err error) error {
+	var gerr *googleapi.Error
+	if !errors.As(err, &gerr) || gerr.Code != 403 || !hasReason(gerr, "keyInvalid") {
+		return nil
+	}
+	return fmt.Errorf("ytclient: API key is invalid or missing required permissions: %w", err)
+}
+
+func hasReason(gerr *googleapi.Error, reasons ...string) bool {
+	for _, e := range gerr.Errors {
+		for _, r := range reasons {
+			if e.Reason == r {
+				return true
+			}
+		}
+	}
+	return false
+}